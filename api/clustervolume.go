@@ -0,0 +1,13 @@
+package portainer
+
+// ClusterVolumeResourceControl and ClusterVolumeGroupResourceControl extend the ResourceControlType enum
+// declared in portainer.go to cover Swarm Cluster Volumes (the CSI-driver-backed volumes exposed via
+// Docker's ClusterVolumeSpec). A cluster volume can be controlled individually, through
+// ClusterVolumeResourceControl, or at the level of its CSI volume group, through
+// ClusterVolumeGroupResourceControl, so that every volume provisioned by the same group inherits the
+// same access rules. They continue straight on from CustomTemplateResourceControl, the last value in that
+// enum, rather than reserving an arbitrary gap.
+const (
+	ClusterVolumeResourceControl ResourceControlType = CustomTemplateResourceControl + 1 + iota
+	ClusterVolumeGroupResourceControl
+)
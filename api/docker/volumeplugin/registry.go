@@ -0,0 +1,77 @@
+package volumeplugin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Enricher is implemented by a volume plugin integration that can retrieve driver-specific metadata
+// (capacity, quota, replica count, backend URL, ...) for a single volume, following the same contract
+// as the Docker Volume Plugin API's VolumeDriver.Get/.List.
+type Enricher interface {
+	Enrich(ctx context.Context, volumeName string) (map[string]interface{}, error)
+}
+
+type cacheEntry struct {
+	metadata  map[string]interface{}
+	expiresAt time.Time
+}
+
+// Registry holds the Enricher registered for each external volume driver (e.g. rexray, portworx,
+// glusterfs) and caches their responses for a limited duration, so Portainer can surface driver-specific
+// volume metadata in the UI without hardcoding support for any particular backend.
+type Registry struct {
+	mu        sync.Mutex
+	enrichers map[string]Enricher
+	cache     map[string]cacheEntry
+	ttl       time.Duration
+}
+
+// NewRegistry returns a Registry whose cached enrichment results expire after ttl.
+func NewRegistry(ttl time.Duration) *Registry {
+	return &Registry{
+		enrichers: make(map[string]Enricher),
+		cache:     make(map[string]cacheEntry),
+		ttl:       ttl,
+	}
+}
+
+// Register associates an Enricher with a volume driver name. Registering an Enricher for a driver that
+// already has one replaces it.
+func (r *Registry) Register(driver string, enricher Enricher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.enrichers[driver] = enricher
+}
+
+// Enrich returns the driver-specific metadata for volumeName, calling out to the Enricher registered for
+// driver and caching the result for the registry's TTL. It returns a nil map without error when no
+// Enricher is registered for driver.
+func (r *Registry) Enrich(ctx context.Context, driver, volumeName string) (map[string]interface{}, error) {
+	r.mu.Lock()
+	enricher, ok := r.enrichers[driver]
+	if !ok {
+		r.mu.Unlock()
+		return nil, nil
+	}
+
+	cacheKey := driver + "/" + volumeName
+	if entry, ok := r.cache[cacheKey]; ok && time.Now().Before(entry.expiresAt) {
+		r.mu.Unlock()
+		return entry.metadata, nil
+	}
+	r.mu.Unlock()
+
+	metadata, err := enricher.Enrich(ctx, volumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.cache[cacheKey] = cacheEntry{metadata: metadata, expiresAt: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+
+	return metadata, nil
+}
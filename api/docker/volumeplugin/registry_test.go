@@ -0,0 +1,88 @@
+package volumeplugin
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type stubEnricher struct {
+	calls    int32
+	metadata map[string]interface{}
+	err      error
+}
+
+func (e *stubEnricher) Enrich(ctx context.Context, volumeName string) (map[string]interface{}, error) {
+	atomic.AddInt32(&e.calls, 1)
+	return e.metadata, e.err
+}
+
+func TestRegistryEnrichReturnsNilForUnregisteredDriver(t *testing.T) {
+	registry := NewRegistry(time.Minute)
+
+	metadata, err := registry.Enrich(context.Background(), "rexray", "myvolume")
+	if err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if metadata != nil {
+		t.Fatalf("expected nil metadata, got %v", metadata)
+	}
+}
+
+func TestRegistryEnrichCachesResultWithinTTL(t *testing.T) {
+	enricher := &stubEnricher{metadata: map[string]interface{}{"Capacity": "10GiB"}}
+
+	registry := NewRegistry(time.Minute)
+	registry.Register("rexray", enricher)
+
+	for i := 0; i < 3; i++ {
+		metadata, err := registry.Enrich(context.Background(), "rexray", "myvolume")
+		if err != nil {
+			t.Fatalf("expected no error, got %s", err)
+		}
+
+		if metadata["Capacity"] != "10GiB" {
+			t.Fatalf("expected cached metadata, got %v", metadata)
+		}
+	}
+
+	if calls := atomic.LoadInt32(&enricher.calls); calls != 1 {
+		t.Fatalf("expected the enricher to be called once, got %d calls", calls)
+	}
+}
+
+func TestRegistryEnrichRefetchesAfterTTLExpires(t *testing.T) {
+	enricher := &stubEnricher{metadata: map[string]interface{}{"Capacity": "10GiB"}}
+
+	registry := NewRegistry(time.Millisecond)
+	registry.Register("rexray", enricher)
+
+	if _, err := registry.Enrich(context.Background(), "rexray", "myvolume"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, err := registry.Enrich(context.Background(), "rexray", "myvolume"); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+
+	if calls := atomic.LoadInt32(&enricher.calls); calls != 2 {
+		t.Fatalf("expected the enricher to be called twice after the cache expired, got %d calls", calls)
+	}
+}
+
+func TestRegistryEnrichPropagatesEnricherError(t *testing.T) {
+	enricher := &stubEnricher{err: errors.New("plugin endpoint unreachable")}
+
+	registry := NewRegistry(time.Minute)
+	registry.Register("rexray", enricher)
+
+	_, err := registry.Enrich(context.Background(), "rexray", "myvolume")
+	if err == nil {
+		t.Fatal("expected an error from the enricher to be propagated")
+	}
+}
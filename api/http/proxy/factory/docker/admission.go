@@ -0,0 +1,262 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/portainer/portainer/api"
+	"github.com/portainer/portainer/api/http/proxy/factory/responseutils"
+)
+
+const (
+	errVolumeAccessDenied           = portainer.Error("Volume access denied by resource control")
+	errVolumeHostPathBindDenied     = portainer.Error("Volume denied: local volumes bound to a host path are not allowed")
+	errVolumePrivilegedDriverDenied = portainer.Error("Volume denied: driver is not allowed on this endpoint")
+
+	volumeOptionsIdentifier = "Options"
+	volumeDeviceOptionKey   = "device"
+)
+
+// VolumeOperation identifies the kind of Docker volume request an admission chain is evaluating.
+type VolumeOperation string
+
+const (
+	VolumeOperationList    VolumeOperation = "list"
+	VolumeOperationInspect VolumeOperation = "inspect"
+	VolumeOperationUpdate  VolumeOperation = "update"
+	VolumeOperationCreate  VolumeOperation = "create"
+	VolumeOperationRemove  VolumeOperation = "rm"
+	VolumeOperationPrune   VolumeOperation = "prune"
+)
+
+// VolumeAdmissionAttributes carries everything a VolumeAdmitter needs to decide whether a volume
+// operation should be allowed, mirroring the attributes object passed to a Kubernetes admission
+// controller. ResourceControl starts out nil and may be populated by an earlier admitter in the chain
+// (e.g. one resolving inheritance) for a later one to act on.
+type VolumeAdmissionAttributes struct {
+	UserID           portainer.UserID
+	UserTeamIDs      []portainer.TeamID
+	EndpointID       portainer.EndpointID
+	IsAdmin          bool
+	EndpointAccess   bool
+	Operation        VolumeOperation
+	ResourceControls []portainer.ResourceControl
+	ResourceControl  *portainer.ResourceControl
+	Volume           map[string]interface{}
+}
+
+// VolumeAdmitter evaluates a single volume operation and returns an error when it is not allowed. A nil
+// error means the admitter has no objection to the operation; it may still have mutated attributes (for
+// example resolving attributes.ResourceControl) for the next admitter in the chain to use.
+type VolumeAdmitter interface {
+	Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error
+}
+
+// VolumeAdmissionChain runs a series of VolumeAdmitter in order and rejects the operation as soon as one
+// of them returns an error.
+type VolumeAdmissionChain struct {
+	admitters []VolumeAdmitter
+}
+
+// NewVolumeAdmissionChain builds a VolumeAdmissionChain from the given admitters, evaluated in order.
+func NewVolumeAdmissionChain(admitters ...VolumeAdmitter) *VolumeAdmissionChain {
+	return &VolumeAdmissionChain{admitters: admitters}
+}
+
+// Admit runs attributes through every admitter in the chain and returns the first error encountered.
+func (c *VolumeAdmissionChain) Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error {
+	for _, admitter := range c.admitters {
+		if err := admitter.Admit(ctx, attributes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StackLabelInheritanceAdmitter resolves the resource control a volume inherits from its owning Swarm
+// stack (via the com.docker.stack.namespace label) when no more specific resource control has been
+// resolved yet. It never rejects by itself; it only populates attributes.ResourceControl for
+// ResourceControlAdmitter to act on.
+type StackLabelInheritanceAdmitter struct{}
+
+func (a *StackLabelInheritanceAdmitter) Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error {
+	if attributes.ResourceControl != nil {
+		return nil
+	}
+
+	volumeLabels := responseutils.GetJSONObject(attributes.Volume, "Labels")
+	if volumeLabels == nil || volumeLabels[volumeLabelForStackIdentifier] == nil {
+		return nil
+	}
+
+	stackIdentifier, ok := volumeLabels[volumeLabelForStackIdentifier].(string)
+	if !ok {
+		return nil
+	}
+
+	attributes.ResourceControl = portainer.GetResourceControlByResourceIDAndType(stackIdentifier, portainer.StackResourceControl, attributes.ResourceControls)
+
+	return nil
+}
+
+// ResourceControlAdmitter implements the resource-control access check that previously lived inline in
+// volumeInspectOperation and filterVolumeList: it resolves the resource control directly attached to the
+// volume (or, for cluster volumes, its CSI volume group) when no earlier admitter has already resolved
+// one, then rejects the operation unless the caller is an administrator, has full endpoint access, or is
+// granted access by the resolved resource control. VolumeOperationCreate is also exempt: the volume
+// doesn't exist yet at that point, so there is no resource control to check against — ownership is
+// established afterwards, when volumeCreateOperation attaches one.
+type ResourceControlAdmitter struct{}
+
+func (a *ResourceControlAdmitter) Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error {
+	if attributes.Operation == VolumeOperationCreate {
+		return nil
+	}
+
+	if attributes.ResourceControl == nil {
+		resourceControlType := portainer.VolumeResourceControl
+		if isClusterVolume(attributes.Volume) {
+			resourceControlType = portainer.ClusterVolumeResourceControl
+		}
+
+		volumeID, _ := attributes.Volume[volumeIdentifier].(string)
+		attributes.ResourceControl = portainer.GetResourceControlByResourceIDAndType(volumeID, resourceControlType, attributes.ResourceControls)
+	}
+
+	if attributes.ResourceControl == nil {
+		if clusterVolumeGroup := extractClusterVolumeGroup(attributes.Volume); clusterVolumeGroup != "" {
+			attributes.ResourceControl = portainer.GetResourceControlByResourceIDAndType(clusterVolumeGroup, portainer.ClusterVolumeGroupResourceControl, attributes.ResourceControls)
+		}
+	}
+
+	if attributes.IsAdmin || attributes.EndpointAccess {
+		return nil
+	}
+
+	if attributes.ResourceControl != nil && portainer.UserCanAccessResource(attributes.UserID, attributes.UserTeamIDs, attributes.ResourceControl) {
+		return nil
+	}
+
+	return errVolumeAccessDenied
+}
+
+// DenyHostPathBindAdmitter rejects local volumes whose "device" option binds a host path (an absolute
+// path), preventing non-admin users from using the volumes API to read or write arbitrary paths on the
+// host.
+type DenyHostPathBindAdmitter struct{}
+
+func (a *DenyHostPathBindAdmitter) Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error {
+	if attributes.IsAdmin {
+		return nil
+	}
+
+	driver, _ := attributes.Volume[volumeDriverIdentifier].(string)
+	if driver != "" && driver != localVolumeDriver {
+		return nil
+	}
+
+	options := responseutils.GetJSONObject(attributes.Volume, volumeOptionsIdentifier)
+	if options == nil {
+		return nil
+	}
+
+	device, ok := options[volumeDeviceOptionKey].(string)
+	if ok && strings.HasPrefix(device, "/") {
+		return errVolumeHostPathBindDenied
+	}
+
+	return nil
+}
+
+// DenyPrivilegedDriverAdmitter rejects volumes backed by a driver an operator has placed on the
+// privileged-driver deny-list for the endpoint, for drivers Portainer has no way of scoping per-user
+// access to. Like DenyHostPathBindAdmitter, it exempts administrators: the deny-list restricts what
+// non-admin users can reach through the proxy, not what drivers exist on the endpoint. The deny-list is
+// safe for concurrent reads against SetDeniedDrivers, so it can be reconfigured at runtime from the
+// settings API without racing in-flight requests.
+type DenyPrivilegedDriverAdmitter struct {
+	mu            sync.RWMutex
+	deniedDrivers map[string]bool
+}
+
+// NewDenyPrivilegedDriverAdmitter returns a DenyPrivilegedDriverAdmitter with an empty deny-list; use
+// SetDeniedDrivers to populate it.
+func NewDenyPrivilegedDriverAdmitter() *DenyPrivilegedDriverAdmitter {
+	return &DenyPrivilegedDriverAdmitter{deniedDrivers: map[string]bool{}}
+}
+
+// SetDeniedDrivers replaces the set of drivers this admitter rejects.
+func (a *DenyPrivilegedDriverAdmitter) SetDeniedDrivers(drivers []string) {
+	deniedDrivers := make(map[string]bool, len(drivers))
+	for _, driver := range drivers {
+		deniedDrivers[driver] = true
+	}
+
+	a.mu.Lock()
+	a.deniedDrivers = deniedDrivers
+	a.mu.Unlock()
+}
+
+func (a *DenyPrivilegedDriverAdmitter) Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error {
+	if attributes.IsAdmin {
+		return nil
+	}
+
+	driver, _ := attributes.Volume[volumeDriverIdentifier].(string)
+
+	a.mu.RLock()
+	denied := a.deniedDrivers[driver]
+	a.mu.RUnlock()
+
+	if denied {
+		return errVolumePrivilegedDriverDenied
+	}
+
+	return nil
+}
+
+// denyPrivilegedDriverAdmitter is the DenyPrivilegedDriverAdmitter instance wired into
+// defaultVolumeAdmissionChain; kept as a package-level var, like resourceControlService and volumeQuotas
+// in volume_provisioning.go, so SetDeniedVolumeDrivers can reconfigure it after the chain is built.
+var denyPrivilegedDriverAdmitter = NewDenyPrivilegedDriverAdmitter()
+
+// SetDeniedVolumeDrivers replaces the set of volume drivers non-admin users are denied from using, so the
+// policy can be changed at runtime (for example from the settings API) without editing Go code. The
+// deny-list is a single package-level one: it applies to every endpoint proxied by this process and is not
+// kept per endpoint.
+func SetDeniedVolumeDrivers(drivers []string) {
+	denyPrivilegedDriverAdmitter.SetDeniedDrivers(drivers)
+}
+
+// defaultVolumeAdmissionChain is the admission chain built once, at package initialization, and shared by
+// every volume endpoint (list, inspect, create, rm, prune) of every proxied Docker endpoint; it is not
+// composed per proxy or per endpoint. Built-in admitters are ordered so that
+// resource-control resolution happens before the access decision, and deny policies run last since they
+// apply regardless of ownership.
+var defaultVolumeAdmissionChain = NewVolumeAdmissionChain(
+	&StackLabelInheritanceAdmitter{},
+	&ResourceControlAdmitter{},
+	&DenyHostPathBindAdmitter{},
+	denyPrivilegedDriverAdmitter,
+)
+
+// admitVolumeOperation runs attributes through the default volume admission chain.
+func admitVolumeOperation(attributes *VolumeAdmissionAttributes) error {
+	return defaultVolumeAdmissionChain.Admit(context.Background(), attributes)
+}
+
+// newVolumeAdmissionAttributes builds the VolumeAdmissionAttributes for volume out of an
+// operation's restrictedDockerOperationContext.
+func newVolumeAdmissionAttributes(volume map[string]interface{}, operation VolumeOperation, operationContext *restrictedDockerOperationContext) *VolumeAdmissionAttributes {
+	return &VolumeAdmissionAttributes{
+		UserID:           operationContext.userID,
+		UserTeamIDs:      operationContext.userTeamIDs,
+		IsAdmin:          operationContext.isAdmin,
+		EndpointAccess:   operationContext.endpointResourceAccess,
+		Operation:        operation,
+		ResourceControls: operationContext.resourceControls,
+		Volume:           volume,
+	}
+}
@@ -0,0 +1,156 @@
+package docker
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDenyHostPathBindAdmitterDeniesHostPathBind(t *testing.T) {
+	admitter := &DenyHostPathBindAdmitter{}
+
+	attributes := &VolumeAdmissionAttributes{
+		Volume: map[string]interface{}{
+			volumeDriverIdentifier: localVolumeDriver,
+			volumeOptionsIdentifier: map[string]interface{}{
+				volumeDeviceOptionKey: "/etc",
+			},
+		},
+	}
+
+	if err := admitter.Admit(context.Background(), attributes); err != errVolumeHostPathBindDenied {
+		t.Fatalf("expected %s, got %v", errVolumeHostPathBindDenied, err)
+	}
+}
+
+func TestDenyHostPathBindAdmitterAllowsAdmin(t *testing.T) {
+	admitter := &DenyHostPathBindAdmitter{}
+
+	attributes := &VolumeAdmissionAttributes{
+		IsAdmin: true,
+		Volume: map[string]interface{}{
+			volumeDriverIdentifier: localVolumeDriver,
+			volumeOptionsIdentifier: map[string]interface{}{
+				volumeDeviceOptionKey: "/etc",
+			},
+		},
+	}
+
+	if err := admitter.Admit(context.Background(), attributes); err != nil {
+		t.Fatalf("expected admin to bypass the host-path check, got %v", err)
+	}
+}
+
+func TestDenyHostPathBindAdmitterAllowsNonLocalDriver(t *testing.T) {
+	admitter := &DenyHostPathBindAdmitter{}
+
+	attributes := &VolumeAdmissionAttributes{
+		Volume: map[string]interface{}{
+			volumeDriverIdentifier: "rexray",
+			volumeOptionsIdentifier: map[string]interface{}{
+				volumeDeviceOptionKey: "/etc",
+			},
+		},
+	}
+
+	if err := admitter.Admit(context.Background(), attributes); err != nil {
+		t.Fatalf("expected non-local driver to bypass the host-path check, got %v", err)
+	}
+}
+
+func TestDenyPrivilegedDriverAdmitterDeniesConfiguredDriver(t *testing.T) {
+	admitter := NewDenyPrivilegedDriverAdmitter()
+	admitter.SetDeniedDrivers([]string{"rexray"})
+
+	attributes := &VolumeAdmissionAttributes{
+		Volume: map[string]interface{}{volumeDriverIdentifier: "rexray"},
+	}
+
+	if err := admitter.Admit(context.Background(), attributes); err != errVolumePrivilegedDriverDenied {
+		t.Fatalf("expected %s, got %v", errVolumePrivilegedDriverDenied, err)
+	}
+}
+
+func TestDenyPrivilegedDriverAdmitterAllowsAdmin(t *testing.T) {
+	admitter := NewDenyPrivilegedDriverAdmitter()
+	admitter.SetDeniedDrivers([]string{"rexray"})
+
+	attributes := &VolumeAdmissionAttributes{
+		IsAdmin: true,
+		Volume:  map[string]interface{}{volumeDriverIdentifier: "rexray"},
+	}
+
+	if err := admitter.Admit(context.Background(), attributes); err != nil {
+		t.Fatalf("expected admin to bypass the privileged-driver deny-list, got %v", err)
+	}
+}
+
+func TestDenyPrivilegedDriverAdmitterAllowsUnlistedDriver(t *testing.T) {
+	admitter := NewDenyPrivilegedDriverAdmitter()
+	admitter.SetDeniedDrivers([]string{"rexray"})
+
+	attributes := &VolumeAdmissionAttributes{
+		Volume: map[string]interface{}{volumeDriverIdentifier: "portworx"},
+	}
+
+	if err := admitter.Admit(context.Background(), attributes); err != nil {
+		t.Fatalf("expected driver not on the deny-list to be allowed, got %v", err)
+	}
+}
+
+func TestDenyPrivilegedDriverAdmitterSetDeniedDriversReplacesList(t *testing.T) {
+	admitter := NewDenyPrivilegedDriverAdmitter()
+	admitter.SetDeniedDrivers([]string{"rexray"})
+	admitter.SetDeniedDrivers([]string{"portworx"})
+
+	attributes := &VolumeAdmissionAttributes{
+		Volume: map[string]interface{}{volumeDriverIdentifier: "rexray"},
+	}
+
+	if err := admitter.Admit(context.Background(), attributes); err != nil {
+		t.Fatalf("expected rexray to no longer be denied after the deny-list was replaced, got %v", err)
+	}
+}
+
+type allowAdmitter struct{ calls *int }
+
+func (a *allowAdmitter) Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error {
+	*a.calls++
+	return nil
+}
+
+type denyAdmitter struct{ err error }
+
+func (a *denyAdmitter) Admit(ctx context.Context, attributes *VolumeAdmissionAttributes) error {
+	return a.err
+}
+
+func TestVolumeAdmissionChainStopsAtFirstDenial(t *testing.T) {
+	var secondCalls int
+
+	chain := NewVolumeAdmissionChain(
+		&denyAdmitter{err: errVolumeAccessDenied},
+		&allowAdmitter{calls: &secondCalls},
+	)
+
+	if err := chain.Admit(context.Background(), &VolumeAdmissionAttributes{}); err != errVolumeAccessDenied {
+		t.Fatalf("expected %s, got %v", errVolumeAccessDenied, err)
+	}
+
+	if secondCalls != 0 {
+		t.Fatalf("expected the chain to short-circuit before the second admitter, got %d calls", secondCalls)
+	}
+}
+
+func TestVolumeAdmissionChainAllowsWhenNoAdmitterObjects(t *testing.T) {
+	var calls int
+
+	chain := NewVolumeAdmissionChain(&allowAdmitter{calls: &calls}, &allowAdmitter{calls: &calls})
+
+	if err := chain.Admit(context.Background(), &VolumeAdmissionAttributes{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("expected both admitters to run, got %d calls", calls)
+	}
+}
@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/portainer/portainer/api"
+)
+
+// resourceControlCreator is the minimal persistence surface volumeCreateOperation needs to auto-attach a
+// resource control to a newly created volume. It is satisfied by portainer.DataStore's ResourceControl()
+// service and wired up via SetResourceControlCreator at application bootstrap.
+type resourceControlCreator interface {
+	Create(resourceControl *portainer.ResourceControl) error
+}
+
+var resourceControlService resourceControlCreator
+
+// SetResourceControlCreator wires the ResourceControl persistence service used to auto-attach resource
+// controls to newly created volumes. It must be called once during application bootstrap, before any
+// volume create request is proxied.
+func SetResourceControlCreator(service resourceControlCreator) {
+	resourceControlService = service
+}
+
+// volumeQuotas holds the per-team volume-count and total-size quotas enforced by volumeCreateOperation, one
+// entry per team. It is configured via SetVolumeQuotas, typically sourced from the settings the
+// administrator configured.
+var volumeQuotas = map[portainer.TeamID]portainer.TeamVolumeQuota{}
+
+// SetVolumeQuotas replaces the per-team volume quotas enforced at volume creation time.
+func SetVolumeQuotas(quotas map[portainer.TeamID]portainer.TeamVolumeQuota) {
+	volumeQuotas = quotas
+}
+
+// teamVolumeQuotaExceeded reports whether letting newVolumeID through would push any of the requesting
+// user's teams over its configured volume quota, based on the volumes the team already owns (derived from
+// the resource controls the operation already loaded) and the volume sizes currently reported by the
+// Docker daemon. UsageData is only ever populated off the disk-usage code path (the same one the Docker
+// CLI uses for "docker system df"), never off a plain volume list, so DiskUsage is used here rather than
+// VolumeList.
+func teamVolumeQuotaExceeded(executor *operationExecutor, newVolumeID string) (bool, error) {
+	if len(volumeQuotas) == 0 {
+		return false, nil
+	}
+
+	diskUsage, err := executor.dockerClient.DiskUsage(context.Background(), types.DiskUsageOptions{})
+	if err != nil {
+		return false, err
+	}
+
+	sizeByVolume := make(map[string]int64)
+	for _, volume := range diskUsage.Volumes {
+		if volume.UsageData != nil {
+			sizeByVolume[volume.Name] = volume.UsageData.Size
+		}
+	}
+
+	for _, teamID := range executor.operationContext.userTeamIDs {
+		quota, ok := volumeQuotas[teamID]
+		if !ok {
+			continue
+		}
+
+		count, totalBytes := teamVolumeUsage(teamID, executor.operationContext.resourceControls, sizeByVolume)
+		count++
+		totalBytes += sizeByVolume[newVolumeID]
+
+		if quota.MaxVolumes > 0 && count > quota.MaxVolumes {
+			return true, nil
+		}
+
+		if quota.MaxBytes > 0 && totalBytes > quota.MaxBytes {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// teamVolumeUsage counts the volumes owned by teamID (volume and cluster volume resource controls granting
+// that team access) and sums their reported size.
+func teamVolumeUsage(teamID portainer.TeamID, resourceControls []portainer.ResourceControl, sizeByVolume map[string]int64) (int, int64) {
+	var count int
+	var totalBytes int64
+
+	for _, resourceControl := range resourceControls {
+		if resourceControl.Type != portainer.VolumeResourceControl && resourceControl.Type != portainer.ClusterVolumeResourceControl {
+			continue
+		}
+
+		for _, access := range resourceControl.TeamAccesses {
+			if access.TeamID == teamID {
+				count++
+				totalBytes += sizeByVolume[resourceControl.ResourceID]
+				break
+			}
+		}
+	}
+
+	return count, totalBytes
+}
@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/portainer/portainer/api"
+)
+
+func TestTeamVolumeUsageCountsOnlyMatchingTeamAccesses(t *testing.T) {
+	const teamID portainer.TeamID = 1
+	const otherTeamID portainer.TeamID = 2
+
+	resourceControls := []portainer.ResourceControl{
+		{
+			ResourceID: "volume-a",
+			Type:       portainer.VolumeResourceControl,
+			TeamAccesses: []portainer.TeamResourceAccess{
+				{TeamID: teamID, AccessLevel: portainer.ReadWriteAccessLevel},
+			},
+		},
+		{
+			ResourceID: "volume-b",
+			Type:       portainer.ClusterVolumeResourceControl,
+			TeamAccesses: []portainer.TeamResourceAccess{
+				{TeamID: teamID, AccessLevel: portainer.ReadWriteAccessLevel},
+			},
+		},
+		{
+			ResourceID: "volume-c",
+			Type:       portainer.VolumeResourceControl,
+			TeamAccesses: []portainer.TeamResourceAccess{
+				{TeamID: otherTeamID, AccessLevel: portainer.ReadWriteAccessLevel},
+			},
+		},
+		{
+			ResourceID: "stack-a",
+			Type:       portainer.StackResourceControl,
+			TeamAccesses: []portainer.TeamResourceAccess{
+				{TeamID: teamID, AccessLevel: portainer.ReadWriteAccessLevel},
+			},
+		},
+	}
+
+	sizeByVolume := map[string]int64{
+		"volume-a": 100,
+		"volume-b": 250,
+		"volume-c": 9999,
+	}
+
+	count, totalBytes := teamVolumeUsage(teamID, resourceControls, sizeByVolume)
+
+	if count != 2 {
+		t.Fatalf("expected 2 volumes owned by the team, got %d", count)
+	}
+
+	if totalBytes != 350 {
+		t.Fatalf("expected 350 bytes of usage, got %d", totalBytes)
+	}
+}
+
+func TestTeamVolumeUsageIgnoresPrivateResourceControls(t *testing.T) {
+	const teamID portainer.TeamID = 1
+
+	resourceControls := []portainer.ResourceControl{
+		{
+			ResourceID: "volume-a",
+			Type:       portainer.VolumeResourceControl,
+		},
+	}
+
+	count, totalBytes := teamVolumeUsage(teamID, resourceControls, map[string]int64{"volume-a": 100})
+
+	if count != 0 || totalBytes != 0 {
+		t.Fatalf("expected no usage for a private resource control, got count=%d bytes=%d", count, totalBytes)
+	}
+}
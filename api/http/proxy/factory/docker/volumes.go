@@ -1,12 +1,22 @@
 package docker
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"log"
 	"net/http"
+	"path"
+	"strconv"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 
 	"github.com/portainer/portainer/api"
+	"github.com/portainer/portainer/api/docker/volumeplugin"
 	"github.com/portainer/portainer/api/http/proxy/factory/responseutils"
 )
 
@@ -14,8 +24,59 @@ const (
 	errDockerVolumeIdentifierNotFound = portainer.Error("Docker volume identifier not found")
 	volumeIdentifier                  = "Name"
 	volumeLabelForStackIdentifier     = "com.docker.stack.namespace"
+	clusterVolumeSpecIdentifier       = "ClusterVolumeSpec"
+	clusterVolumeGroupIdentifier      = "Group"
+	volumeDriverIdentifier            = "Driver"
+	localVolumeDriver                 = "local"
+	volumeOwnerLabel                  = "io.portainer.owner"
+	volumeTeamLabel                   = "io.portainer.team"
 )
 
+// volumePluginRegistry holds the per-driver metadata enrichers used to decorate volumes backed by an
+// external plugin (rexray, portworx, glusterfs, ...) with driver-supplied fields. Enrichers are registered
+// by the packages that implement them; this registry only owns the caching and dispatch.
+var volumePluginRegistry = volumeplugin.NewRegistry(30 * time.Second)
+
+// volumePluginEnrichTimeout bounds how long a single volume list/inspect request will wait on an external
+// volume plugin's metadata endpoint, so a slow or hung plugin can't block every request touching its
+// driver indefinitely.
+const volumePluginEnrichTimeout = 2 * time.Second
+
+// enrichVolumeObjectWithPluginMetadata merges driver-supplied metadata (capacity, quota, replica count,
+// backend URL, ...) into volumeObject when the volume is backed by a driver other than "local" and an
+// Enricher is registered for it. The volume is returned unchanged, and the failure logged, when no
+// enrichment is available or the Enricher doesn't respond within volumePluginEnrichTimeout.
+func enrichVolumeObjectWithPluginMetadata(volumeObject map[string]interface{}) map[string]interface{} {
+	driver, ok := volumeObject[volumeDriverIdentifier].(string)
+	if !ok || driver == "" || driver == localVolumeDriver {
+		return volumeObject
+	}
+
+	volumeID, ok := volumeObject[volumeIdentifier].(string)
+	if !ok {
+		return volumeObject
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), volumePluginEnrichTimeout)
+	defer cancel()
+
+	metadata, err := volumePluginRegistry.Enrich(ctx, driver, volumeID)
+	if err != nil {
+		log.Printf("[WARN] [docker,volume] failed to enrich volume %s metadata from %s plugin: %s", volumeID, driver, err)
+		return volumeObject
+	}
+
+	if metadata == nil {
+		return volumeObject
+	}
+
+	for key, value := range metadata {
+		volumeObject[key] = value
+	}
+
+	return volumeObject
+}
+
 func getInheritedResourceControlFromVolumeLabels(dockerClient *client.Client, volumeID string, resourceControls []portainer.ResourceControl) (*portainer.ResourceControl, error) {
 	network, err := dockerClient.VolumeInspect(context.Background(), volumeID)
 	if err != nil {
@@ -46,11 +107,10 @@ func volumeListOperation(response *http.Response, executor *operationExecutor) e
 	if responseObject["Volumes"] != nil {
 		volumeData := responseObject["Volumes"].([]interface{})
 
-		if executor.operationContext.isAdmin || executor.operationContext.endpointResourceAccess {
-			volumeData, err = decorateVolumeList(volumeData, executor.operationContext.resourceControls)
-		} else {
-			volumeData, err = filterVolumeList(volumeData, executor.operationContext)
-		}
+		// filterVolumeList always runs the admission chain, admins and users with full endpoint access
+		// included. The deny policies (host-path binds, privileged drivers) exempt administrators, so they
+		// apply to every non-admin user, including those with full endpoint access.
+		volumeData, err = filterVolumeList(volumeData, executor.operationContext)
 		if err != nil {
 			return err
 		}
@@ -62,6 +122,59 @@ func volumeListOperation(response *http.Response, executor *operationExecutor) e
 	return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
 }
 
+// volumeUpdateRequest verifies, before the PUT is forwarded to the Docker daemon, that the caller has
+// access to the cluster volume being updated (based on any existing resource control). Unlike the other
+// operations in this file, which run once Docker has already acted on the proxied request, this must be
+// wired as a pre-forward request interceptor at proxy construction, so an unauthorized update never
+// reaches the daemon in the first place: deciding after the fact would just be cosmetic, since the update
+// already happened.
+// Docker only exposes PUT /volumes/{name} for Swarm Cluster Volumes, so this is only ever reached for
+// cluster volumes. The resource controls checked are the one inherited from the owning Swarm stack and
+// the one attached to the volume by name; a CSI volume group's resource control is not consulted here.
+func volumeUpdateRequest(request *http.Request, executor *operationExecutor) error {
+	volumeID := extractVolumeIdentifierFromRequestPath(request)
+	if volumeID == "" {
+		return errDockerVolumeIdentifierNotFound
+	}
+
+	resourceControl, err := getInheritedResourceControlFromVolumeLabels(executor.dockerClient, volumeID, executor.operationContext.resourceControls)
+	if err != nil {
+		return err
+	}
+
+	if resourceControl == nil {
+		resourceControl = portainer.GetResourceControlByResourceIDAndType(volumeID, portainer.ClusterVolumeResourceControl, executor.operationContext.resourceControls)
+	}
+
+	attributes := newVolumeAdmissionAttributes(map[string]interface{}{volumeIdentifier: volumeID}, VolumeOperationUpdate, executor.operationContext)
+	attributes.ResourceControl = resourceControl
+
+	return admitVolumeOperation(attributes)
+}
+
+// volumeUpdateOperation extracts the response as a JSON object and decorates it with the resource control
+// metadata for the updated volume. Access was already verified pre-forward by volumeUpdateRequest; this
+// only rewrites the response for display, it does not make an access decision.
+func volumeUpdateOperation(response *http.Response, executor *operationExecutor) error {
+	responseObject, err := responseutils.GetResponseAsJSONOBject(response)
+	if err != nil {
+		return err
+	}
+
+	if responseObject[volumeIdentifier] == nil {
+		return errDockerVolumeIdentifierNotFound
+	}
+
+	attributes := newVolumeAdmissionAttributes(responseObject, VolumeOperationUpdate, executor.operationContext)
+	_ = admitVolumeOperation(attributes) // only used here to resolve ResourceControl; access was already checked by volumeUpdateRequest
+
+	if attributes.ResourceControl != nil {
+		responseObject = decorateObject(responseObject, attributes.ResourceControl)
+	}
+
+	return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
+}
+
 // volumeInspectOperation extracts the response as a JSON object, verify that the user
 // has access to the volume based on any existing resource control and either rewrite an access denied response
 // or a decorated volume.
@@ -77,88 +190,290 @@ func volumeInspectOperation(response *http.Response, executor *operationExecutor
 		return errDockerVolumeIdentifierNotFound
 	}
 
-	resourceControl := findInheritedVolumeResourceControl(responseObject, executor.operationContext.resourceControls)
-	if resourceControl == nil && (executor.operationContext.isAdmin || executor.operationContext.endpointResourceAccess) {
-		return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
+	responseObject = enrichVolumeObjectWithPluginMetadata(responseObject)
+
+	attributes := newVolumeAdmissionAttributes(responseObject, VolumeOperationInspect, executor.operationContext)
+	if err := admitVolumeOperation(attributes); err != nil {
+		return responseutils.RewriteAccessDeniedResponse(response)
 	}
 
-	if executor.operationContext.isAdmin || executor.operationContext.endpointResourceAccess || portainer.UserCanAccessResource(executor.operationContext.userID, executor.operationContext.userTeamIDs, resourceControl) {
-		responseObject = decorateObject(responseObject, resourceControl)
-		return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
+	if attributes.ResourceControl != nil {
+		responseObject = decorateObject(responseObject, attributes.ResourceControl)
 	}
 
-	return responseutils.RewriteAccessDeniedResponse(response)
+	return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
 }
 
-// findInheritedVolumeResourceControl will search for a resource control object associated to the service or
-// inherited from a Swarm stack (based on labels).
-func findInheritedVolumeResourceControl(responseObject map[string]interface{}, resourceControls []portainer.ResourceControl) *portainer.ResourceControl {
-	volumeID := responseObject[volumeIdentifier].(string)
+// volumeCreateRequest verifies, before the POST is forwarded to the Docker daemon, that the volume being
+// created does not violate an endpoint-wide deny policy (a host-path bind or a driver on the deny-list).
+// Like volumeUpdateRequest/volumeDeleteRequest, this must run pre-forward: by the time a create response
+// exists, Docker has already created the volume and it could already be mounted into a container, so
+// filtering it out of later list/inspect views would be too late. The request body is read and restored
+// so the original request still reaches Docker unchanged.
+func volumeCreateRequest(request *http.Request, executor *operationExecutor) error {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var requestObject map[string]interface{}
+	if err := json.Unmarshal(body, &requestObject); err != nil {
+		return err
+	}
 
-	resourceControl := portainer.GetResourceControlByResourceIDAndType(volumeID, portainer.VolumeResourceControl, resourceControls)
-	if resourceControl != nil {
-		return resourceControl
+	volumeObject := map[string]interface{}{
+		volumeIdentifier:        requestObject["Name"],
+		volumeDriverIdentifier:  requestObject["Driver"],
+		volumeOptionsIdentifier: requestObject["DriverOpts"],
 	}
 
-	volumeLabels := extractVolumeLabelsFromVolumeInspectObject(responseObject)
-	if volumeLabels != nil {
-		if volumeLabels[volumeLabelForStackIdentifier] != nil {
-			inheritedSwarmStackIdentifier := volumeLabels[volumeLabelForStackIdentifier].(string)
-			resourceControl = portainer.GetResourceControlByResourceIDAndType(inheritedSwarmStackIdentifier, portainer.StackResourceControl, resourceControls)
+	attributes := newVolumeAdmissionAttributes(volumeObject, VolumeOperationCreate, executor.operationContext)
 
-			if resourceControl != nil {
-				return resourceControl
+	return admitVolumeOperation(attributes)
+}
+
+// volumeCreateOperation extracts the response as a JSON object, enforces the requesting user's team volume
+// quota (rolling back the just-created volume if it would be exceeded), then auto-attaches a resource
+// control bound to the requesting user, mirroring how container and stack resource controls are created,
+// before rewriting the response. The deny policies themselves were already enforced pre-forward by
+// volumeCreateRequest.
+func volumeCreateOperation(response *http.Response, executor *operationExecutor) error {
+	responseObject, err := responseutils.GetResponseAsJSONOBject(response)
+	if err != nil {
+		return err
+	}
+
+	if responseObject[volumeIdentifier] == nil {
+		return errDockerVolumeIdentifierNotFound
+	}
+
+	volumeID := responseObject[volumeIdentifier].(string)
+
+	if !executor.operationContext.isAdmin {
+		exceeded, err := teamVolumeQuotaExceeded(executor, volumeID)
+		if err != nil {
+			return err
+		}
+
+		if exceeded {
+			if err := executor.dockerClient.VolumeRemove(context.Background(), volumeID, true); err != nil {
+				log.Printf("[ERROR] [docker,volume] failed to remove volume %s after it exceeded the team quota: %s", volumeID, err)
 			}
+
+			return responseutils.RewriteAccessDeniedResponse(response)
 		}
 	}
 
-	return nil
+	responseObject = stampVolumeOwnershipLabels(responseObject, executor.operationContext.userID, executor.operationContext.userTeamIDs)
+
+	if resourceControlService != nil {
+		resourceControlType := portainer.VolumeResourceControl
+		if isClusterVolume(responseObject) {
+			resourceControlType = portainer.ClusterVolumeResourceControl
+		}
+
+		resourceControl := portainer.NewPrivateResourceControl(volumeID, resourceControlType, executor.operationContext.userID)
+
+		// Grant the user's team(s) access on the resource control as well, not just the creating user, so
+		// teamVolumeUsage (which only counts resource controls with a matching TeamAccesses entry) actually
+		// sees volumes the team already owns when enforcing the quota on the next creation.
+		for _, teamID := range executor.operationContext.userTeamIDs {
+			resourceControl.TeamAccesses = append(resourceControl.TeamAccesses, portainer.TeamResourceAccess{
+				TeamID:      teamID,
+				AccessLevel: portainer.ReadWriteAccessLevel,
+			})
+		}
+
+		if err := resourceControlService.Create(&resourceControl); err != nil {
+			return err
+		}
+
+		responseObject = decorateObject(responseObject, &resourceControl)
+	}
+
+	return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
 }
 
-// extractVolumeLabelsFromVolumeInspectObject retrieve the Labels of the volume if present.
-// Volume schema reference: https://docs.docker.com/engine/api/v1.28/#operation/VolumeInspect
-func extractVolumeLabelsFromVolumeInspectObject(responseObject map[string]interface{}) map[string]interface{} {
-	// Labels are stored under Labels
-	return responseutils.GetJSONObject(responseObject, "Labels")
+// stampVolumeOwnershipLabels records the owning user and, when the user belongs to one, their first team
+// on the Labels of the volume object returned in the create response. Only that response is changed: the
+// volume stored by the Docker daemon does not carry these labels, so they are not visible to anything
+// reading the volume directly from the Docker API.
+func stampVolumeOwnershipLabels(volumeObject map[string]interface{}, userID portainer.UserID, userTeamIDs []portainer.TeamID) map[string]interface{} {
+	labels, ok := volumeObject["Labels"].(map[string]interface{})
+	if !ok || labels == nil {
+		labels = make(map[string]interface{})
+	}
+
+	labels[volumeOwnerLabel] = strconv.Itoa(int(userID))
+	if len(userTeamIDs) > 0 {
+		labels[volumeTeamLabel] = strconv.Itoa(int(userTeamIDs[0]))
+	}
+
+	volumeObject["Labels"] = labels
+
+	return volumeObject
+}
+
+// volumeDeleteRequest verifies, before the DELETE is forwarded to the Docker daemon, that the user has
+// access to the volume being removed, based on any existing resource control (including one inherited
+// from the owning Swarm stack). This must be wired as a pre-forward request interceptor at proxy
+// construction: by the time a response exists, Docker has already deleted the volume, so rejecting the
+// response at that point would not undo the deletion — it would just hide it from the caller while the
+// volume stays gone.
+func volumeDeleteRequest(request *http.Request, executor *operationExecutor) error {
+	volumeID := extractVolumeIdentifierFromRequestPath(request)
+	if volumeID == "" {
+		return errDockerVolumeIdentifierNotFound
+	}
+
+	resourceControl, err := getInheritedResourceControlFromVolumeLabels(executor.dockerClient, volumeID, executor.operationContext.resourceControls)
+	if err != nil {
+		return err
+	}
+
+	if resourceControl == nil {
+		resourceControl = portainer.GetResourceControlByResourceIDAndType(volumeID, portainer.ClusterVolumeResourceControl, executor.operationContext.resourceControls)
+	}
+
+	attributes := newVolumeAdmissionAttributes(map[string]interface{}{volumeIdentifier: volumeID}, VolumeOperationRemove, executor.operationContext)
+	attributes.ResourceControl = resourceControl
+
+	return admitVolumeOperation(attributes)
 }
 
-// extractVolumeLabelsFromVolumeListObject retrieve the Labels of the volume if present.
-// Volume schema reference: https://docs.docker.com/engine/api/v1.28/#operation/VolumeList
-func extractVolumeLabelsFromVolumeListObject(responseObject map[string]interface{}) map[string]interface{} {
-	// Labels are stored under Labels
-	return responseutils.GetJSONObject(responseObject, "Labels")
+// extractVolumeIdentifierFromRequestPath extracts the volume name from a DELETE or PUT /volumes/{name}
+// request, used by the pre-forward request checks that run before a response (or, for DELETE, any
+// response body) exists to read it from.
+func extractVolumeIdentifierFromRequestPath(request *http.Request) string {
+	if request == nil {
+		return ""
+	}
+
+	return path.Base(request.URL.Path)
 }
 
-// decorateVolumeList loops through all volumes and decorates any volume with an existing resource control.
-// Resource controls checks are based on: resource identifier, stack identifier (from label).
-// Volume object schema reference: https://docs.docker.com/engine/api/v1.28/#operation/VolumeList
-func decorateVolumeList(volumeData []interface{}, resourceControls []portainer.ResourceControl) ([]interface{}, error) {
-	decoratedVolumeData := make([]interface{}, 0)
+// volumePruneRequest neutralizes the forwarded /volumes/prune request for restricted users before it
+// reaches the Docker daemon. The generic prune endpoint is not trustworthy for them: it is daemon-wide and
+// reclaims every unused volume on the endpoint regardless of who owns it, so forwarding it unmodified would
+// already have swept every other team's unused volumes before pruneAccessibleVolumes gets a chance to run
+// its own, per-user scoped prune off the response. Rewriting the request's filters to one that can never
+// match a real volume makes the forwarded prune itself a no-op, so the daemon-wide sweep never happens;
+// the actual, admission-checked prune is then carried out entirely by pruneAccessibleVolumes.
+func volumePruneRequest(request *http.Request, executor *operationExecutor) error {
+	if executor.operationContext.isAdmin || executor.operationContext.endpointResourceAccess {
+		return nil
+	}
 
-	for _, volume := range volumeData {
+	query := request.URL.Query()
+	query.Set("filters", `{"label":["io.portainer.restricted-prune-noop"]}`)
+	request.URL.RawQuery = query.Encode()
 
-		volumeObject := volume.(map[string]interface{})
-		if volumeObject[volumeIdentifier] == nil {
-			return nil, errDockerVolumeIdentifierNotFound
+	return nil
+}
+
+// volumePruneOperation extracts the response as a JSON object and rewrites it. For admins and users with
+// full endpoint access, Docker's own daemon-wide prune result is passed through unchanged. For restricted
+// users, the forwarded request was already neutralized pre-forward by volumePruneRequest, so Docker's
+// result here reflects nothing having been reclaimed; pruneAccessibleVolumes replaces it with individual,
+// admission-checked deletes scoped to the caller's own accessible, unused volumes, and the response is
+// synthesized from those targeted deletes rather than from Docker's (no-op) prune result.
+func volumePruneOperation(response *http.Response, executor *operationExecutor) error {
+	if executor.operationContext.isAdmin || executor.operationContext.endpointResourceAccess {
+		responseObject, err := responseutils.GetResponseAsJSONOBject(response)
+		if err != nil {
+			return err
 		}
 
-		volumeID := volumeObject[volumeIdentifier].(string)
-		volumeObject = decorateResourceWithAccessControl(volumeObject, volumeID, resourceControls, portainer.VolumeResourceControl)
+		return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
+	}
+
+	return pruneAccessibleVolumes(response, executor)
+}
 
-		volumeLabels := extractVolumeLabelsFromVolumeListObject(volumeObject)
-		volumeObject = decorateResourceWithAccessControlFromLabel(volumeLabels, volumeObject, volumeLabelForStackIdentifier, resourceControls, portainer.StackResourceControl)
+// pruneAccessibleVolumes lists the volumes currently unused on the endpoint itself, admits each one
+// individually through the admission chain, and removes only the ones the caller is entitled to prune,
+// rather than trusting the response of Docker's own daemon-wide /volumes/prune.
+func pruneAccessibleVolumes(response *http.Response, executor *operationExecutor) error {
+	ctx := context.Background()
 
-		decoratedVolumeData = append(decoratedVolumeData, volumeObject)
+	danglingVolumes, err := executor.dockerClient.VolumeList(ctx, filters.NewArgs(filters.Arg("dangling", "true")))
+	if err != nil {
+		return err
 	}
 
-	return decoratedVolumeData, nil
+	// VolumeList never populates UsageData; sizes come from the disk-usage endpoint instead.
+	diskUsage, err := executor.dockerClient.DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return err
+	}
+
+	sizeByVolume := make(map[string]int64)
+	for _, volume := range diskUsage.Volumes {
+		if volume.UsageData != nil {
+			sizeByVolume[volume.Name] = volume.UsageData.Size
+		}
+	}
+
+	volumesDeleted := make([]interface{}, 0)
+	var spaceReclaimed int64
+
+	for _, volume := range danglingVolumes.Volumes {
+		attributes := newVolumeAdmissionAttributes(map[string]interface{}{volumeIdentifier: volume.Name}, VolumeOperationPrune, executor.operationContext)
+		if err := admitVolumeOperation(attributes); err != nil {
+			continue
+		}
+
+		if err := executor.dockerClient.VolumeRemove(ctx, volume.Name, false); err != nil {
+			continue
+		}
+
+		volumesDeleted = append(volumesDeleted, volume.Name)
+		spaceReclaimed += sizeByVolume[volume.Name]
+	}
+
+	responseObject := map[string]interface{}{
+		"VolumesDeleted": volumesDeleted,
+		"SpaceReclaimed": spaceReclaimed,
+	}
+
+	return responseutils.RewriteResponse(response, responseObject, http.StatusOK)
+}
+
+// isClusterVolume returns true if the volume is a Swarm Cluster Volume, i.e. a CSI-driver-backed volume
+// carrying a ClusterVolumeSpec (Group, AccessMode, Scope).
+// Volume object schema reference: https://docs.docker.com/engine/api/v1.42/#operation/VolumeInspect
+func isClusterVolume(volumeObject map[string]interface{}) bool {
+	return extractClusterVolumeSpec(volumeObject) != nil
+}
+
+// extractClusterVolumeSpec retrieves the ClusterVolumeSpec of the volume if present.
+func extractClusterVolumeSpec(volumeObject map[string]interface{}) map[string]interface{} {
+	return responseutils.GetJSONObject(volumeObject, clusterVolumeSpecIdentifier)
 }
 
-// filterVolumeList loops through all volumes and filters authorized volumes (access granted to the user based on existing resource control).
-// Authorized volumes are decorated during the process.
+// extractClusterVolumeGroup retrieves the CSI volume group the cluster volume belongs to, if any.
+func extractClusterVolumeGroup(volumeObject map[string]interface{}) string {
+	clusterVolumeSpec := extractClusterVolumeSpec(volumeObject)
+	if clusterVolumeSpec == nil || clusterVolumeSpec[clusterVolumeGroupIdentifier] == nil {
+		return ""
+	}
+
+	group, ok := clusterVolumeSpec[clusterVolumeGroupIdentifier].(string)
+	if !ok {
+		return ""
+	}
+
+	return group
+}
+
+// filterVolumeList loops through all volumes and filters authorized volumes (access granted to the user
+// based on existing resource control, or, for non-admin users with full endpoint access, the deny
+// policies in the admission chain; admins see every volume). Authorized volumes are decorated during the
+// process.
 // Resource controls checks are based on: resource identifier, stack identifier (from label).
 // Volume object schema reference: https://docs.docker.com/engine/api/v1.28/#operation/VolumeList
-func filterVolumeList(volumeData []interface{}, context *restrictedDockerOperationContext) ([]interface{}, error) {
+func filterVolumeList(volumeData []interface{}, operationContext *restrictedDockerOperationContext) ([]interface{}, error) {
 	filteredVolumeData := make([]interface{}, 0)
 
 	for _, volume := range volumeData {
@@ -167,16 +482,18 @@ func filterVolumeList(volumeData []interface{}, context *restrictedDockerOperati
 			return nil, errDockerVolumeIdentifierNotFound
 		}
 
-		volumeID := volumeObject[volumeIdentifier].(string)
-		volumeObject, access := applyResourceAccessControl(volumeObject, volumeID, context, portainer.VolumeResourceControl)
-		if !access {
-			volumeLabels := extractVolumeLabelsFromVolumeListObject(volumeObject)
-			volumeObject, access = applyResourceAccessControlFromLabel(volumeLabels, volumeObject, volumeLabelForStackIdentifier, context, portainer.StackResourceControl)
+		volumeObject = enrichVolumeObjectWithPluginMetadata(volumeObject)
+
+		attributes := newVolumeAdmissionAttributes(volumeObject, VolumeOperationList, operationContext)
+		if err := admitVolumeOperation(attributes); err != nil {
+			continue
 		}
 
-		if access {
-			filteredVolumeData = append(filteredVolumeData, volumeObject)
+		if attributes.ResourceControl != nil {
+			volumeObject = decorateObject(volumeObject, attributes.ResourceControl)
 		}
+
+		filteredVolumeData = append(filteredVolumeData, volumeObject)
 	}
 
 	return filteredVolumeData, nil
@@ -0,0 +1,9 @@
+package portainer
+
+// TeamVolumeQuota caps how many volumes a team may own and how many bytes those volumes may consume in
+// aggregate. It is enforced by the volume proxy when a member of the team creates a new volume.
+type TeamVolumeQuota struct {
+	TeamID     TeamID
+	MaxVolumes int
+	MaxBytes   int64
+}